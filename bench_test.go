@@ -0,0 +1,53 @@
+package intset_test
+
+import (
+	"testing"
+
+	"github.com/wolf/intset"
+)
+
+// These benchmarks compare IntSet (sparse, O(min(n,m)) set operations, ~16 bytes/possible-element) against
+// BitIntSet (dense, O(universeSize/64) set operations, 1 bit/possible-element) across a sparse and a dense
+// workload, so the two can be picked between on real numbers rather than guesswork.
+
+const benchUniverseSize = 1 << 20 // 1Mi possible elements
+
+func benchValues(n int, stride int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = (i * stride) % benchUniverseSize
+	}
+	return values
+}
+
+func BenchmarkSparseUnionSparseWorkload(b *testing.B) {
+	values := benchValues(1000, 997)
+	other := intset.New(benchUniverseSize, values...)
+	for i := 0; i < b.N; i++ {
+		intset.New(benchUniverseSize, values...).Union(other)
+	}
+}
+
+func BenchmarkDenseUnionSparseWorkload(b *testing.B) {
+	values := benchValues(1000, 997)
+	other := intset.NewDense(benchUniverseSize, values...)
+	for i := 0; i < b.N; i++ {
+		intset.NewDense(benchUniverseSize, values...).Union(other)
+	}
+}
+
+func BenchmarkSparseUnionDenseWorkload(b *testing.B) {
+	values := benchValues(benchUniverseSize/2, 1)
+	other := intset.New(benchUniverseSize, values...)
+	for i := 0; i < b.N; i++ {
+		intset.New(benchUniverseSize, values...).Union(other)
+	}
+}
+
+func BenchmarkDenseUnionDenseWorkload(b *testing.B) {
+	values := benchValues(benchUniverseSize/2, 1)
+	other := intset.NewDense(benchUniverseSize, values...)
+	for i := 0; i < b.N; i++ {
+		intset.NewDense(benchUniverseSize, values...).Union(other)
+	}
+}
@@ -0,0 +1,259 @@
+package intset
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// BitIntSet is a dense alternative to IntSet: instead of two []int of length universeSize (~16 bytes per
+// possible element), it packs one bit per possible element into a []uint64, at the cost of O(universeSize/64)
+// set operations instead of IntSet's O(min(n,m)).  Prefer BitIntSet when the universe is large and densely
+// populated; prefer IntSet when the set is sparse relative to its universe.  NewDense and NewSparse build
+// one or the other from the same call shape.
+type BitIntSet struct {
+	universeSize int
+	words        []uint64
+	length       int
+	lengthDirty  bool
+}
+
+var _ Interface = (*BitIntSet)(nil)
+
+// NewDense creates a new, empty BitIntSet with the given universe size, then adds values to it exactly as New does.
+func NewDense(universeSize int, values ...int) *BitIntSet {
+	result := &BitIntSet{universeSize: universeSize, words: make([]uint64, (universeSize+63)/64)}
+	return result.Add(values...).(*BitIntSet)
+}
+
+// NewSparse creates a new IntSet; it exists alongside NewDense so callers can pick a representation by name.
+func NewSparse(universeSize int, values ...int) *IntSet {
+	return New(universeSize, values...)
+}
+
+// UniverseSize is 1 greater than the largest value that can be a member of the set.
+func (b *BitIntSet) UniverseSize() int {
+	return b.universeSize
+}
+
+func (b *BitIntSet) Empty() bool {
+	return b.Length() == 0
+}
+
+// Length is cached from a full word-wise popcount and only recomputed after a mutation invalidates the cache.
+
+// Length is O(1) when the cache is valid, O(universeSize/64) when it must be recomputed.
+func (b *BitIntSet) Length() int {
+	if b.lengthDirty {
+		count := 0
+		for _, w := range b.words {
+			count += bits.OnesCount64(w)
+		}
+		b.length = count
+		b.lengthDirty = false
+	}
+	return b.length
+}
+
+// Contains returns true if the given value is a member of the receiver set.
+
+// Contains is O(1).
+func (b *BitIntSet) Contains(value int) bool {
+	if value < 0 || value >= b.universeSize {
+		return false
+	}
+	return b.words[value/64]&(uint64(1)<<uint(value%64)) != 0
+}
+
+// Values returns a new slice containing (in no particular order) all the elements in the receiver set.
+
+// Values is O(universeSize/64 + n), n the number of elements in the receiver set.
+func (b *BitIntSet) Values() []int {
+	result := make([]int, 0, b.Length())
+	b.Each(func(v int) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// Each calls f once for each element of the receiver set, in ascending order, by repeatedly pulling the
+// lowest set bit out of each word with TrailingZeros64.  If f returns false, Each stops early.
+
+// Each is O(universeSize/64 + n), n the number of elements in the receiver set.
+func (b *BitIntSet) Each(f func(int) bool) {
+	for wordIndex, w := range b.words {
+		for w != 0 {
+			bit := bits.TrailingZeros64(w)
+			if !f(wordIndex*64 + bit) {
+				return
+			}
+			w &= w - 1
+		}
+	}
+}
+
+// Add puts a new element into the receiver set, if that element is in the set's universe.
+
+// Add is O(1) per value.
+// Add returns the receiver set to allow method chaining.
+func (b *BitIntSet) Add(values ...int) Interface {
+	for _, v := range values {
+		if v < 0 || v >= b.universeSize {
+			continue
+		}
+		b.words[v/64] |= uint64(1) << uint(v%64)
+	}
+	b.lengthDirty = true
+	return b
+}
+
+// Remove removes elements from the receiver set.
+
+// Remove is O(1) per value.
+// Remove returns the receiver set to allow method chaining.
+func (b *BitIntSet) Remove(values ...int) Interface {
+	for _, v := range values {
+		if v < 0 || v >= b.universeSize {
+			continue
+		}
+		b.words[v/64] &^= uint64(1) << uint(v%64)
+	}
+	b.lengthDirty = true
+	return b
+}
+
+// Union with a receiver updates the receiver set to also include all the elements in other.  When other is
+// also a *BitIntSet, the two bitmaps are combined word-wise with |; otherwise Union falls back to visiting
+// other's elements one at a time.
+
+// Union is O(universeSize/64) against another BitIntSet, O(m) otherwise, m the number of elements in other.
+// Union returns the receiver set to allow method chaining.
+func (b *BitIntSet) Union(other Interface) Interface {
+	if ob, ok := other.(*BitIntSet); ok {
+		for i := range b.words {
+			if i < len(ob.words) {
+				b.words[i] |= ob.words[i]
+			}
+		}
+		if extra := b.universeSize % 64; extra != 0 && len(b.words) > 0 {
+			b.words[len(b.words)-1] &= uint64(1)<<uint(extra) - 1
+		}
+		b.lengthDirty = true
+		return b
+	}
+	other.Each(func(v int) bool {
+		b.Add(v)
+		return true
+	})
+	return b
+}
+
+// Difference with a receiver updates the receiver set to remove any element that also appears in other.
+// When other is also a *BitIntSet, the two bitmaps are combined word-wise with &^; otherwise Difference
+// falls back to visiting other's elements one at a time.
+
+// Difference is O(universeSize/64) against another BitIntSet, O(m) otherwise, m the number of elements in other.
+// Difference returns the receiver set to allow method chaining.
+func (b *BitIntSet) Difference(other Interface) Interface {
+	if ob, ok := other.(*BitIntSet); ok {
+		for i := range b.words {
+			if i < len(ob.words) {
+				b.words[i] &^= ob.words[i]
+			}
+		}
+		b.lengthDirty = true
+		return b
+	}
+	other.Each(func(v int) bool {
+		b.Remove(v)
+		return true
+	})
+	return b
+}
+
+// Intersection with a receiver updates the receiver set to remove any element that does not also appear in
+// other.  When other is also a *BitIntSet, the two bitmaps are combined word-wise with &; otherwise
+// Intersection falls back to probing the receiver's own elements against other.Contains.
+
+// Intersection is O(universeSize/64) against another BitIntSet, O(universeSize/64 + n) otherwise, n the number
+// of elements in the receiver set.
+// Intersection returns the receiver set to allow method chaining.
+func (b *BitIntSet) Intersection(other Interface) Interface {
+	if ob, ok := other.(*BitIntSet); ok {
+		for i := range b.words {
+			if i < len(ob.words) {
+				b.words[i] &= ob.words[i]
+			} else {
+				b.words[i] = 0
+			}
+		}
+		b.lengthDirty = true
+		return b
+	}
+	for wordIndex, w := range b.words {
+		for w != 0 {
+			bit := bits.TrailingZeros64(w)
+			if !other.Contains(wordIndex*64 + bit) {
+				b.words[wordIndex] &^= uint64(1) << uint(bit)
+			}
+			w &= w - 1
+		}
+	}
+	b.lengthDirty = true
+	return b
+}
+
+// Copy duplicates a set.
+
+// The new set contains exactly the same members as the receiver set.  Copy is O(universeSize/64).
+func (b *BitIntSet) Copy() Interface {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitIntSet{universeSize: b.universeSize, words: words, lengthDirty: true}
+}
+
+func (b *BitIntSet) String() string {
+	result := "["
+	first := true
+	b.Each(func(v int) bool {
+		if !first {
+			result += " "
+		}
+		result += fmt.Sprint(v)
+		first = false
+		return true
+	})
+	result += "]"
+	return result
+}
+
+// Choose returns an element at random from the receiver set.  The set itself is not modified.
+
+// Unlike IntSet.Choose, which indexes its backing slice directly, BitIntSet.Choose walks the bitmap looking
+// for the chosen element, since a bitmap has no direct index-to-element mapping.
+// Choose is O(universeSize/64).
+func (b *BitIntSet) Choose() (int, error) {
+	length := b.Length()
+	if length == 0 {
+		return 0, nil
+	}
+	max := big.NewInt(int64(length))
+	ip, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return 0, err
+	}
+	target := ip.Int64()
+	var choice int
+	var i int64
+	b.Each(func(v int) bool {
+		if i == target {
+			choice = v
+			return false
+		}
+		i++
+		return true
+	})
+	return choice, nil
+}
@@ -0,0 +1,59 @@
+package intset_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/wolf/intset"
+)
+
+func (*Suite) TestBitIntSetBasics(c *gc.C) {
+	s := intset.NewDense(100, 1, 2, 64, 99)
+	c.Assert(s.Length(), gc.Equals, 4)
+	c.Assert(s.Contains(64), gc.Equals, true)
+	c.Assert(s.Contains(3), gc.Equals, false)
+	s.Add(3)
+	c.Assert(s.Contains(3), gc.Equals, true)
+	s.Remove(3)
+	c.Assert(s.Contains(3), gc.Equals, false)
+	c.Assert(s.Contains(1000), gc.Equals, false)
+}
+
+func (*Suite) TestBitIntSetUnionDifferenceIntersection(c *gc.C) {
+	a := intset.NewDense(100, 1, 2, 64, 65)
+	b := intset.NewDense(100, 2, 65, 70)
+
+	union := a.Copy().Union(b)
+	c.Assert(union.Length(), gc.Equals, 5)
+
+	intersection := a.Copy().Intersection(b)
+	c.Assert(intersection.Length(), gc.Equals, 2)
+	c.Assert(intersection.Contains(2), gc.Equals, true)
+	c.Assert(intersection.Contains(65), gc.Equals, true)
+
+	difference := a.Copy().Difference(b)
+	c.Assert(difference.Length(), gc.Equals, 2)
+	c.Assert(difference.Contains(1), gc.Equals, true)
+	c.Assert(difference.Contains(64), gc.Equals, true)
+}
+
+func (*Suite) TestBitIntSetInteropWithIntSet(c *gc.C) {
+	dense := intset.NewDense(100, 1, 2, 64)
+	sparse := intset.NewSparse(100, 2, 64, 70).AsInterface()
+
+	union := dense.Copy().Union(sparse)
+	c.Assert(union.Length(), gc.Equals, 4)
+
+	intersection := dense.Copy().Intersection(sparse)
+	c.Assert(intersection.Length(), gc.Equals, 2)
+}
+
+func (*Suite) TestBitIntSetEachAndValues(c *gc.C) {
+	s := intset.NewDense(200, 1, 63, 64, 128, 199)
+	seen := map[int]bool{}
+	s.Each(func(v int) bool {
+		seen[v] = true
+		return true
+	})
+	c.Assert(seen, gc.HasLen, 5)
+	c.Assert(s.Values(), gc.HasLen, 5)
+}
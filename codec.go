@@ -0,0 +1,99 @@
+package intset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// wireFormat is the on-the-wire shape for both the JSON and gob encodings of an IntSet.  Carrying Universe
+// alongside Values means a round trip preserves UniverseSize(), not just membership.
+type wireFormat struct {
+	Universe int   `json:"universe"`
+	Values   []int `json:"values"`
+}
+
+// OutOfRangeError is returned by UnmarshalJSON and GobDecode when the encoded data names a value that
+// doesn't fit the encoded universe.
+type OutOfRangeError struct {
+	Value    int
+	Universe int
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("intset: value %d is outside universe [0, %d)", e.Value, e.Universe)
+}
+
+// maxWireUniverse bounds the universe size UnmarshalJSON and GobDecode will accept. New allocates two
+// slices of this length, so decoding wire data from an untrusted source with no upper bound would let it
+// dictate an arbitrarily large allocation.
+const maxWireUniverse = 1 << 24
+
+// InvalidUniverseError is returned by UnmarshalJSON and GobDecode when the encoded data names a universe
+// size that isn't usable: negative, or larger than maxWireUniverse.
+type InvalidUniverseError struct {
+	Universe int
+}
+
+func (e *InvalidUniverseError) Error() string {
+	return fmt.Sprintf("intset: invalid universe %d (must be in [0, %d])", e.Universe, maxWireUniverse)
+}
+
+func fromWireFormat(payload wireFormat) (*IntSet, error) {
+	if payload.Universe < 0 || payload.Universe > maxWireUniverse {
+		return nil, &InvalidUniverseError{Universe: payload.Universe}
+	}
+	result := New(payload.Universe)
+	for _, v := range payload.Values {
+		if v < 0 || v >= payload.Universe {
+			return nil, &OutOfRangeError{Value: v, Universe: payload.Universe}
+		}
+		result.Add(v)
+	}
+	return result, nil
+}
+
+// MarshalJSON encodes the receiver set as {"universe": <UniverseSize()>, "values": [...]}.
+func (is *IntSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wireFormat{Universe: is.UniverseSize(), Values: is.Values()})
+}
+
+// UnmarshalJSON decodes a set previously encoded by MarshalJSON.  It returns an *OutOfRangeError if the
+// encoded values don't all fit the encoded universe.
+func (is *IntSet) UnmarshalJSON(data []byte) error {
+	var payload wireFormat
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	result, err := fromWireFormat(payload)
+	if err != nil {
+		return err
+	}
+	*is = *result
+	return nil
+}
+
+// GobEncode encodes the receiver set for use with encoding/gob.
+func (is *IntSet) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(wireFormat{Universe: is.UniverseSize(), Values: is.Values()}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a set previously encoded by GobEncode.  It returns an *OutOfRangeError if the encoded
+// values don't all fit the encoded universe.
+func (is *IntSet) GobDecode(data []byte) error {
+	var payload wireFormat
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&payload); err != nil {
+		return err
+	}
+	result, err := fromWireFormat(payload)
+	if err != nil {
+		return err
+	}
+	*is = *result
+	return nil
+}
@@ -0,0 +1,49 @@
+package intset_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/wolf/intset"
+)
+
+func (*Suite) TestJSONRoundTrip(c *gc.C) {
+	s := intset.New(52, 1, 2, 4, 5)
+	data, err := json.Marshal(s)
+	c.Assert(err, gc.IsNil)
+
+	var decoded intset.IntSet
+	c.Assert(json.Unmarshal(data, &decoded), gc.IsNil)
+	c.Assert(decoded.UniverseSize(), gc.Equals, 52)
+	c.Assert(decoded.Length(), gc.Equals, 4)
+	c.Assert(decoded.IsEqual(s), gc.Equals, true)
+}
+
+func (*Suite) TestJSONUnmarshalRejectsOutOfRange(c *gc.C) {
+	var decoded intset.IntSet
+	err := json.Unmarshal([]byte(`{"universe":5,"values":[1,7]}`), &decoded)
+	c.Assert(err, gc.FitsTypeOf, &intset.OutOfRangeError{})
+}
+
+func (*Suite) TestJSONUnmarshalRejectsInvalidUniverse(c *gc.C) {
+	var decoded intset.IntSet
+	err := json.Unmarshal([]byte(`{"universe":-1,"values":[]}`), &decoded)
+	c.Assert(err, gc.FitsTypeOf, &intset.InvalidUniverseError{})
+
+	err = json.Unmarshal([]byte(`{"universe":9223372036854775807,"values":[]}`), &decoded)
+	c.Assert(err, gc.FitsTypeOf, &intset.InvalidUniverseError{})
+}
+
+func (*Suite) TestGobRoundTrip(c *gc.C) {
+	s := intset.New(52, 1, 2, 4, 5)
+	var buf bytes.Buffer
+	c.Assert(gob.NewEncoder(&buf).Encode(s), gc.IsNil)
+
+	var decoded intset.IntSet
+	c.Assert(gob.NewDecoder(&buf).Decode(&decoded), gc.IsNil)
+	c.Assert(decoded.UniverseSize(), gc.Equals, 52)
+	c.Assert(decoded.IsEqual(s), gc.Equals, true)
+}
@@ -0,0 +1,96 @@
+package intset
+
+// intSetAdapter gives an *IntSet an Interface-shaped view without changing IntSet's own chainable methods
+// (which return *IntSet, not Interface, so that Add, Grow, Pop, and the rest keep working exactly as before).
+type intSetAdapter struct {
+	is *IntSet
+}
+
+var _ Interface = (*intSetAdapter)(nil)
+
+// AsInterface returns an Interface-shaped view of the receiver set, for code that wants to accept either an
+// IntSet or a SafeIntSet without caring which.
+func (is *IntSet) AsInterface() Interface {
+	return &intSetAdapter{is: is}
+}
+
+func (a *intSetAdapter) Add(values ...int) Interface {
+	a.is.Add(values...)
+	return a
+}
+
+func (a *intSetAdapter) Remove(values ...int) Interface {
+	a.is.Remove(values...)
+	return a
+}
+
+func (a *intSetAdapter) Contains(value int) bool {
+	return a.is.Contains(value)
+}
+
+func (a *intSetAdapter) Length() int {
+	return a.is.Length()
+}
+
+func (a *intSetAdapter) Values() []int {
+	return a.is.Values()
+}
+
+func (a *intSetAdapter) Each(f func(int) bool) {
+	a.is.Each(f)
+}
+
+func (a *intSetAdapter) Choose() (int, error) {
+	return a.is.Choose()
+}
+
+func (a *intSetAdapter) Copy() Interface {
+	return &intSetAdapter{is: a.is.Copy()}
+}
+
+func (a *intSetAdapter) String() string {
+	return a.is.String()
+}
+
+// Union takes the word-wise-free path (is.Union(other.is)) when other is also wrapping an *IntSet, and
+// otherwise falls back to visiting other's elements one at a time.
+func (a *intSetAdapter) Union(other Interface) Interface {
+	if oa, ok := other.(*intSetAdapter); ok {
+		a.is.Union(oa.is)
+		return a
+	}
+	other.Each(func(v int) bool {
+		a.is.Add(v)
+		return true
+	})
+	return a
+}
+
+func (a *intSetAdapter) Difference(other Interface) Interface {
+	if oa, ok := other.(*intSetAdapter); ok {
+		a.is.Difference(oa.is)
+		return a
+	}
+	other.Each(func(v int) bool {
+		a.is.Remove(v)
+		return true
+	})
+	return a
+}
+
+func (a *intSetAdapter) Intersection(other Interface) Interface {
+	if oa, ok := other.(*intSetAdapter); ok {
+		a.is.Intersection(oa.is)
+		return a
+	}
+	matches := make([]int, 0, a.is.Length())
+	a.is.Each(func(v int) bool {
+		if other.Contains(v) {
+			matches = append(matches, v)
+		}
+		return true
+	})
+	a.is.Clear()
+	a.is.Add(matches...)
+	return a
+}
@@ -32,27 +32,64 @@ For an error like this, the benefits of ignoring it may exceed the benefits of
 reporting it.  The size of a set's universe is conceptually part of the type
 of the set.  Trying to add a value outside that universe is a programming
 error, not a run-time error.
+
+Policy (3) is available as an opt-in: Grow, AddGrow, and NewAutoGrow expand a set's universe in place
+instead of dropping out-of-range values.  Add and New keep their original ignore-it behavior, so existing
+callers are unaffected.
 */
 
 package intset
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"iter"
 	"math/big"
 )
 
+// ErrEmpty is returned by Pop when the receiver set has no elements to remove.
+var ErrEmpty = errors.New("intset: set is empty")
+
 type IntSet struct {
 	length int
 	keys   []int
 	set    []int
 }
 
+// Interface is the common surface shared by IntSet (via AsInterface) and SafeIntSet, so that code which
+// doesn't care about concurrency safety can be written against whichever implementation the caller picks at
+// construction time (New vs NewSafe).
+
+// IntSet's own methods keep returning *IntSet, to preserve their existing chainable signatures (Add,
+// Union, Grow, Pop, and friends).  IntSet itself therefore does not satisfy Interface directly; call
+// AsInterface on an *IntSet to get an Interface-shaped view of it.
+type Interface interface {
+	Add(values ...int) Interface
+	Remove(values ...int) Interface
+	Contains(value int) bool
+	Union(other Interface) Interface
+	Difference(other Interface) Interface
+	Intersection(other Interface) Interface
+	Length() int
+	Values() []int
+	Each(f func(int) bool)
+	Choose() (int, error)
+	Copy() Interface
+	String() string
+}
+
 func New(universeSize int, values ...int) *IntSet {
 	result := &IntSet{0, make([]int, universeSize), make([]int, universeSize)}
 	return result.Add(values...)
 }
 
+// NewAutoGrow is like New, except values outside the initial universe grow the set's universe to fit them,
+// via AddGrow, instead of being silently dropped.
+func NewAutoGrow(universeSize int, values ...int) *IntSet {
+	return New(universeSize).AddGrow(values...)
+}
+
 // Copy duplicates a set.
 
 // The new set contains exactly the same members as the receiver set.  Copy is
@@ -106,6 +143,40 @@ func (is *IntSet) Values() []int {
 	return result
 }
 
+// Each calls f once for each element of the receiver set, in no particular order.  If f returns false, Each
+// stops early without visiting the remaining elements.
+
+// Each is O(n), n the number of elements in the receiver set, and performs no allocation.
+func (is *IntSet) Each(f func(int) bool) {
+	for _, v := range is.set[:is.length] {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+// Range calls f once for each element of the receiver set, in no particular order.  Unlike Each, the
+// visit cannot be aborted early; use Range when Values would otherwise be used just to loop over the
+// members without keeping the slice around.
+
+// Range is O(n), n the number of elements in the receiver set, and performs no allocation.
+func (is *IntSet) Range(f func(int)) {
+	is.Each(func(v int) bool {
+		f(v)
+		return true
+	})
+}
+
+// All returns an iter.Seq[int] over the elements of the receiver set, in no particular order, so callers
+// can write `for v := range set.All()`.
+
+// All performs no allocation; iteration stops early if the range body breaks, exactly like Each.
+func (is *IntSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		is.Each(yield)
+	}
+}
+
 // Add puts a new element into the receiver set, if that element is in the set's universe.
 
 // Add is O(1) for a single value and O(n), n the number of values to add, for a list of values.
@@ -143,6 +214,56 @@ func (is *IntSet) Remove(values ...int) *IntSet {
 	return is
 }
 
+// Grow expands the receiver set's universe to newUniverseSize, preserving every existing member and its
+// key index.  If newUniverseSize is not larger than the current universe, Grow does nothing.
+
+// Grow is O(newUniverseSize).
+// Grow returns the receiver set to allow method chaining.
+func (is *IntSet) Grow(newUniverseSize int) *IntSet {
+	if newUniverseSize <= len(is.keys) {
+		return is
+	}
+	newKeys := make([]int, newUniverseSize)
+	newSet := make([]int, newUniverseSize)
+	copy(newKeys, is.keys)
+	copy(newSet, is.set)
+	is.keys = newKeys
+	is.set = newSet
+	return is
+}
+
+// AddGrow is like Add, except a value outside the receiver's current universe grows the universe to fit it,
+// instead of being silently dropped, as the package doc's policy (3) describes.  The universe at least
+// doubles on each growth, so that a sequence of AddGrow calls is amortized O(1) each.
+
+// AddGrow returns the receiver set to allow method chaining.
+func (is *IntSet) AddGrow(values ...int) *IntSet {
+	for _, v := range values {
+		if v < 0 {
+			continue
+		}
+		if v >= len(is.keys) {
+			newUniverseSize := 2 * len(is.keys)
+			if v+1 > newUniverseSize {
+				newUniverseSize = v + 1
+			}
+			is.Grow(newUniverseSize)
+		}
+		is.Add(v)
+	}
+	return is
+}
+
+// Clear empties the receiver set.  Clear does not reallocate the backing keys and set slices; it just
+// resets the receiver's length to zero, instead of removing the elements one at a time as Remove does.
+
+// Clear is O(1).
+// Clear returns the receiver set to allow method chaining.
+func (is *IntSet) Clear() *IntSet {
+	is.length = 0
+	return is
+}
+
 // Union with a receiver updates the receiver set to also include all the elements in other.
 
 // Union is O(n), n the number of elements in other.
@@ -173,6 +294,108 @@ func Difference(lhs, rhs *IntSet) *IntSet {
 	return lhs.Copy().Difference(rhs)
 }
 
+// Intersection with a receiver updates the receiver set to remove any element that does not also appear in other.
+
+// Intersection is O(min(n,m)), n the number of elements in the receiver set, m the number of elements in other.
+// Intersection returns the receiver set to allow method chaining.
+func (is *IntSet) Intersection(other *IntSet) *IntSet {
+	small, large := is, other
+	if other.length < is.length {
+		small, large = other, is
+	}
+	matches := make([]int, 0, small.length)
+	for _, v := range small.set[:small.length] {
+		if large.Contains(v) {
+			matches = append(matches, v)
+		}
+	}
+	is.length = 0
+	return is.Add(matches...)
+}
+
+// Intersection with two arguments produces a new set that contains exactly the elements that appear in both lhs and rhs.
+
+// The result's universe is the larger of lhs's and rhs's universes.
+// Intersection is O(min(n,m)), n the number of elements in lhs, m the number of elements in rhs.
+func Intersection(lhs, rhs *IntSet) *IntSet {
+	universe := lhs.UniverseSize()
+	if rhs.UniverseSize() > universe {
+		universe = rhs.UniverseSize()
+	}
+	return New(universe, lhs.set[:lhs.length]...).Intersection(rhs)
+}
+
+// SymmetricDifference with a receiver updates the receiver set to contain exactly the elements that appear in
+// is or other, but not both.
+
+// SymmetricDifference is O(n+m), n the number of elements in the receiver set, m the number of elements in other.
+// Values of other that fall outside the receiver's universe are silently dropped, per the package's usual
+// out-of-range policy.
+// SymmetricDifference returns the receiver set to allow method chaining.
+func (is *IntSet) SymmetricDifference(other *IntSet) *IntSet {
+	common := Intersection(is, other)
+	return is.Union(other).Difference(common)
+}
+
+// SymmetricDifference with two arguments produces a new set that contains exactly the elements that appear in
+// lhs or rhs, but not both.
+
+// The result's universe is the larger of lhs's and rhs's universes, so that members of whichever set has the
+// larger universe are never dropped.
+// SymmetricDifference is O(n+m), n the number of elements in lhs, m the number of elements in rhs.
+func SymmetricDifference(lhs, rhs *IntSet) *IntSet {
+	universe := lhs.UniverseSize()
+	if rhs.UniverseSize() > universe {
+		universe = rhs.UniverseSize()
+	}
+	return New(universe, lhs.set[:lhs.length]...).SymmetricDifference(rhs)
+}
+
+// IsSubset returns true if every element of the receiver set is also a member of other.
+
+// IsSubset is O(n), n the number of elements in the receiver set.
+func (is *IntSet) IsSubset(other *IntSet) bool {
+	if is.length > other.length {
+		return false
+	}
+	for _, v := range is.set[:is.length] {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if the receiver set contains every element of other.
+
+// IsSuperset is O(m), m the number of elements in other.
+func (is *IntSet) IsSuperset(other *IntSet) bool {
+	return other.IsSubset(is)
+}
+
+// IsEqual returns true if the receiver set and other contain exactly the same elements.
+
+// IsEqual is O(n), n the number of elements in the receiver set.
+func (is *IntSet) IsEqual(other *IntSet) bool {
+	return is.length == other.length && is.IsSubset(other)
+}
+
+// IsDisjoint returns true if the receiver set and other share no elements.
+
+// IsDisjoint is O(min(n,m)), n the number of elements in the receiver set, m the number of elements in other.
+func (is *IntSet) IsDisjoint(other *IntSet) bool {
+	small, large := is, other
+	if other.length < is.length {
+		small, large = other, is
+	}
+	for _, v := range small.set[:small.length] {
+		if large.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
 // Choose returns an element at random from the receiver set.  The set itself is not modified.
 
 // Choose is the order of complexity of rand.Int
@@ -188,3 +411,29 @@ func (is *IntSet) Choose() (choice int, err error) {
 	choice = is.set[ip.Int64()]
 	return
 }
+
+// Pop chooses an element at random from the receiver set, removes it, and returns it.  Pop is Choose and
+// Remove fused into a single operation: because the random index is already in hand, removal is a plain
+// swap-to-tail, with no need to re-probe is.keys the way Remove does.
+
+// Pop is the order of complexity of rand.Int.
+// Pop returns ErrEmpty if the receiver set has no elements.
+func (is *IntSet) Pop() (int, error) {
+	if is.Empty() {
+		return 0, ErrEmpty
+	}
+	max := big.NewInt(int64(is.length))
+	ip, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return 0, err
+	}
+	index := int(ip.Int64())
+	choice := is.set[index]
+	is.length--
+	lastValue := is.set[is.length]
+	if index != is.length {
+		is.set[index] = lastValue
+		is.keys[lastValue] = index
+	}
+	return choice, nil
+}
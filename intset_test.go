@@ -28,7 +28,40 @@ func (*Suite) TestAddInRange(c *gc.C) {
 }
 
 func (*Suite) TestAddOutOfRange(c *gc.C) {
-	c.Fatal("TODO")
+	s := intset.New(5, 1, 2)
+	s.Add(7)
+	c.Assert(s.Contains(7), gc.Equals, false)
+	c.Assert(s.Length(), gc.Equals, 2)
+}
+
+func (*Suite) TestGrow(c *gc.C) {
+	s := intset.New(5, 1, 2, 4)
+	s.Grow(10)
+	c.Assert(s.UniverseSize(), gc.Equals, 10)
+	c.Assert(s.Contains(1), gc.Equals, true)
+	c.Assert(s.Contains(2), gc.Equals, true)
+	c.Assert(s.Contains(4), gc.Equals, true)
+	s.Add(7)
+	c.Assert(s.Contains(7), gc.Equals, true)
+
+	s.Grow(3) // smaller than the current universe, so this is a no-op
+	c.Assert(s.UniverseSize(), gc.Equals, 10)
+}
+
+func (*Suite) TestAddGrow(c *gc.C) {
+	s := intset.New(5, 1, 2)
+	s.AddGrow(7)
+	c.Assert(s.UniverseSize() > 5, gc.Equals, true)
+	c.Assert(s.Contains(1), gc.Equals, true)
+	c.Assert(s.Contains(7), gc.Equals, true)
+}
+
+func (*Suite) TestNewAutoGrow(c *gc.C) {
+	s := intset.NewAutoGrow(5, 1, 2, 9)
+	c.Assert(s.Contains(1), gc.Equals, true)
+	c.Assert(s.Contains(2), gc.Equals, true)
+	c.Assert(s.Contains(9), gc.Equals, true)
+	c.Assert(s.UniverseSize() > 9, gc.Equals, true)
 }
 
 func (*Suite) TestRemove(c *gc.C) {
@@ -46,3 +79,109 @@ func (*Suite) TestDifference(c *gc.C) {
 func (*Suite) TestChoose(c *gc.C) {
 	c.Fatal("TODO: BONUS: test that Choose() provides a good source of randomness")
 }
+
+func (*Suite) TestIntersection(c *gc.C) {
+	a := intset.New(10, 1, 2, 3, 4)
+	b := intset.New(10, 3, 4, 5, 6)
+	result := intset.Intersection(a, b)
+	c.Assert(result.Length(), gc.Equals, 2)
+	c.Assert(result.Contains(3), gc.Equals, true)
+	c.Assert(result.Contains(4), gc.Equals, true)
+	c.Assert(result.Contains(1), gc.Equals, false)
+
+	a.Intersection(b)
+	c.Assert(a.Length(), gc.Equals, 2)
+	c.Assert(a.Contains(3), gc.Equals, true)
+	c.Assert(a.Contains(4), gc.Equals, true)
+}
+
+func (*Suite) TestSymmetricDifference(c *gc.C) {
+	a := intset.New(10, 1, 2, 3, 4)
+	b := intset.New(10, 3, 4, 5, 6)
+	result := intset.SymmetricDifference(a, b)
+	c.Assert(result.Length(), gc.Equals, 4)
+	for _, v := range []int{1, 2, 5, 6} {
+		c.Assert(result.Contains(v), gc.Equals, true)
+	}
+	for _, v := range []int{3, 4} {
+		c.Assert(result.Contains(v), gc.Equals, false)
+	}
+}
+
+func (*Suite) TestIsSubsetIsSupersetIsEqual(c *gc.C) {
+	a := intset.New(10, 1, 2)
+	b := intset.New(10, 1, 2, 3)
+	c.Assert(a.IsSubset(b), gc.Equals, true)
+	c.Assert(b.IsSubset(a), gc.Equals, false)
+	c.Assert(b.IsSuperset(a), gc.Equals, true)
+	c.Assert(a.IsSuperset(b), gc.Equals, false)
+	c.Assert(a.IsEqual(intset.New(10, 2, 1)), gc.Equals, true)
+	c.Assert(a.IsEqual(b), gc.Equals, false)
+}
+
+func (*Suite) TestIsDisjoint(c *gc.C) {
+	a := intset.New(10, 1, 2, 3)
+	b := intset.New(10, 4, 5, 6)
+	c.Assert(a.IsDisjoint(b), gc.Equals, true)
+	b.Add(3)
+	c.Assert(a.IsDisjoint(b), gc.Equals, false)
+}
+
+func (*Suite) TestEach(c *gc.C) {
+	s := intset.New(10, 1, 2, 3, 4)
+	seen := map[int]bool{}
+	s.Each(func(v int) bool {
+		seen[v] = true
+		return true
+	})
+	c.Assert(seen, gc.HasLen, 4)
+
+	count := 0
+	s.Each(func(v int) bool {
+		count++
+		return false
+	})
+	c.Assert(count, gc.Equals, 1)
+}
+
+func (*Suite) TestRange(c *gc.C) {
+	s := intset.New(10, 1, 2, 3, 4)
+	seen := map[int]bool{}
+	s.Range(func(v int) {
+		seen[v] = true
+	})
+	c.Assert(seen, gc.HasLen, 4)
+}
+
+func (*Suite) TestPop(c *gc.C) {
+	s := intset.New(10, 1, 2, 3)
+	seen := map[int]bool{}
+	for i := 0; i < 3; i++ {
+		v, err := s.Pop()
+		c.Assert(err, gc.IsNil)
+		c.Assert(seen[v], gc.Equals, false)
+		seen[v] = true
+	}
+	c.Assert(s.Length(), gc.Equals, 0)
+
+	_, err := s.Pop()
+	c.Assert(err, gc.Equals, intset.ErrEmpty)
+}
+
+func (*Suite) TestClear(c *gc.C) {
+	s := intset.New(10, 1, 2, 3)
+	s.Clear()
+	c.Assert(s.Length(), gc.Equals, 0)
+	c.Assert(s.Contains(1), gc.Equals, false)
+	s.Add(4)
+	c.Assert(s.Contains(4), gc.Equals, true)
+}
+
+func (*Suite) TestAll(c *gc.C) {
+	s := intset.New(10, 1, 2, 3, 4)
+	seen := map[int]bool{}
+	for v := range s.All() {
+		seen[v] = true
+	}
+	c.Assert(seen, gc.HasLen, 4)
+}
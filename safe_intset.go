@@ -0,0 +1,178 @@
+package intset
+
+import "sync"
+
+// SafeIntSet wraps an IntSet with a sync.RWMutex so it can be shared between goroutines.  It implements
+// Interface, so code that doesn't need concurrency safety can be written against Interface and handed
+// either a plain IntSet (from New, viewed through AsInterface) or a SafeIntSet (from NewSafe) without caring which.
+
+// Union, Difference, and Intersection snapshot other's values before taking the receiver's lock, so passing
+// the same SafeIntSet as both receiver and other is safe too: the snapshot read takes and releases its own
+// read lock before the receiver's write lock is ever acquired.
+type SafeIntSet struct {
+	mu sync.RWMutex
+	is *IntSet
+}
+
+var _ Interface = (*SafeIntSet)(nil)
+
+// NewSafe creates a new, empty SafeIntSet with the given universe size, then adds values to it exactly as New does.
+func NewSafe(universeSize int, values ...int) *SafeIntSet {
+	return &SafeIntSet{is: New(universeSize, values...)}
+}
+
+// Copy duplicates a set.
+
+// The new set contains exactly the same members as the receiver set.  Copy is
+// O(n) where n is the number of elements actually in the receiver set.
+func (s *SafeIntSet) Copy() Interface {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &SafeIntSet{is: s.is.Copy()}
+}
+
+func (s *SafeIntSet) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.String()
+}
+
+func (s *SafeIntSet) Length() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.Length()
+}
+
+func (s *SafeIntSet) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.Empty()
+}
+
+// UniverseSize is 1 greater than the largest value that can be a member of the set.
+func (s *SafeIntSet) UniverseSize() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.UniverseSize()
+}
+
+// Contains returns true if the given value is a member of the receiver set.
+
+// Contains is O(1).
+func (s *SafeIntSet) Contains(value int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.Contains(value)
+}
+
+// Values returns a new slice containing (in no particular order) all the elements in the receiver set.
+
+// Values is O(n), n the number of elements in the receiver set.
+func (s *SafeIntSet) Values() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.Values()
+}
+
+// Each calls f once for each element of the receiver set, in no particular order.  If f returns false, Each
+// stops early without visiting the remaining elements.  f is called while the receiver's read lock is held,
+// so it must not call back into the same SafeIntSet.
+func (s *SafeIntSet) Each(f func(int) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.is.Each(f)
+}
+
+// Add puts a new element into the receiver set, if that element is in the set's universe.
+
+// Add returns the receiver set to allow method chaining.
+func (s *SafeIntSet) Add(values ...int) Interface {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.is.Add(values...)
+	return s
+}
+
+// Remove removes elements from the receiver set.
+
+// Remove returns the receiver set to allow method chaining.
+func (s *SafeIntSet) Remove(values ...int) Interface {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.is.Remove(values...)
+	return s
+}
+
+// Union with a receiver updates the receiver set to also include all the elements in other.  other is
+// snapshotted into a plain slice before the receiver's lock is taken, so neither a concurrent
+// s1.Union(s2) / s2.Union(s1) pair between two distinct SafeIntSets nor passing the same SafeIntSet as both
+// receiver and other can lock-order-invert.
+
+// Union returns the receiver set to allow method chaining.
+func (s *SafeIntSet) Union(other Interface) Interface {
+	values := other.Values()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.is.Add(values...)
+	return s
+}
+
+// Difference with a receiver updates the receiver set to remove any element that also appears in other.
+// See Union for why other is snapshotted before the receiver's lock is taken.
+
+// Difference returns the receiver set to allow method chaining.
+func (s *SafeIntSet) Difference(other Interface) Interface {
+	values := other.Values()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.is.Remove(values...)
+	return s
+}
+
+// Intersection with a receiver updates the receiver set to remove any element that does not also appear in
+// other.  See Union for why other is snapshotted before the receiver's lock is taken.
+
+// Intersection returns the receiver set to allow method chaining.
+func (s *SafeIntSet) Intersection(other Interface) Interface {
+	values := other.Values()
+	present := make(map[int]bool, len(values))
+	for _, v := range values {
+		present[v] = true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	matches := make([]int, 0, s.is.Length())
+	s.is.Each(func(v int) bool {
+		if present[v] {
+			matches = append(matches, v)
+		}
+		return true
+	})
+	s.is.Clear()
+	s.is.Add(matches...)
+	return s
+}
+
+// Choose returns an element at random from the receiver set.  The set itself is not modified.
+func (s *SafeIntSet) Choose() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.is.Choose()
+}
+
+// Pop chooses an element at random from the receiver set, removes it, and returns it.
+func (s *SafeIntSet) Pop() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.is.Pop()
+}
+
+// Clear empties the receiver set without reallocating its backing storage.
+
+// Clear returns the receiver set to allow method chaining.
+func (s *SafeIntSet) Clear() *SafeIntSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.is.Clear()
+	return s
+}
@@ -0,0 +1,114 @@
+package intset_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/wolf/intset"
+)
+
+func (*Suite) TestSafeIntSetBasics(c *gc.C) {
+	s := intset.NewSafe(10, 1, 2, 4)
+	c.Assert(s.Contains(3), gc.Equals, false)
+	s.Add(3)
+	c.Assert(s.Contains(3), gc.Equals, true)
+	c.Assert(s.Length(), gc.Equals, 4)
+	s.Remove(3)
+	c.Assert(s.Contains(3), gc.Equals, false)
+}
+
+func (*Suite) TestSafeIntSetUnionDifferenceIntersection(c *gc.C) {
+	a := intset.NewSafe(10, 1, 2, 3)
+	b := intset.NewSafe(10, 3, 4, 5)
+
+	union := a.Copy().Union(b)
+	c.Assert(union.Length(), gc.Equals, 5)
+
+	intersection := a.Copy().Intersection(b)
+	c.Assert(intersection.Length(), gc.Equals, 1)
+	c.Assert(intersection.Contains(3), gc.Equals, true)
+
+	difference := a.Copy().Difference(b)
+	c.Assert(difference.Length(), gc.Equals, 2)
+	c.Assert(difference.Contains(3), gc.Equals, false)
+}
+
+func (*Suite) TestInterfaceAcceptsEitherImplementation(c *gc.C) {
+	var plain intset.Interface = intset.New(10, 1, 2, 3).AsInterface()
+	var safe intset.Interface = intset.NewSafe(10, 1, 2, 3)
+	c.Assert(plain.Length(), gc.Equals, safe.Length())
+	c.Assert(plain.Contains(2), gc.Equals, true)
+	c.Assert(safe.Contains(2), gc.Equals, true)
+}
+
+// barrierInterface wraps an Interface and blocks the first call to Values or Each until the test releases
+// it, so a test can force two goroutines to be inside their own Union's critical section at the same time
+// before either one is allowed to look at the other set.
+type barrierInterface struct {
+	intset.Interface
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *barrierInterface) wait() {
+	b.once.Do(func() { close(b.entered) })
+	<-b.release
+}
+
+func (b *barrierInterface) Values() []int {
+	b.wait()
+	return b.Interface.Values()
+}
+
+func (b *barrierInterface) Each(f func(int) bool) {
+	b.wait()
+	b.Interface.Each(f)
+}
+
+// TestSafeIntSetCrossUnionConcurrent exercises two distinct SafeIntSets Union-ing each other at the same
+// time.  barrierInterface forces both goroutines to be inside their own Union call before either is
+// allowed to read the other set, which is exactly the interleaving that used to deadlock: Union took the
+// receiver's write lock, then called other.Each, which took the other SafeIntSet's read lock while the
+// first write lock was still held.  Union now snapshots other.Values() before locking the receiver, so
+// neither goroutine holds its own lock while blocked on the other.
+func TestSafeIntSetCrossUnionConcurrent(t *testing.T) {
+	a := intset.NewSafe(100, 1, 2, 3)
+	b := intset.NewSafe(100, 4, 5, 6)
+
+	wrappedA := &barrierInterface{Interface: a, entered: make(chan struct{}), release: make(chan struct{})}
+	wrappedB := &barrierInterface{Interface: b, entered: make(chan struct{}), release: make(chan struct{})}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		a.Union(wrappedB)
+		done <- struct{}{}
+	}()
+	go func() {
+		b.Union(wrappedA)
+		done <- struct{}{}
+	}()
+
+	// Don't release either goroutine until both have reached the barrier; this is the moment at which the
+	// old implementation would already be holding its own write lock.
+	<-wrappedA.entered
+	<-wrappedB.entered
+	close(wrappedA.release)
+	close(wrappedB.release)
+
+	timeout := time.After(2 * time.Second)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-timeout:
+			t.Fatal("Union deadlocked between two distinct SafeIntSets")
+		}
+	}
+
+	if a.Length() != 6 || b.Length() != 6 {
+		t.Fatalf("expected both sets to have 6 elements, got a=%d b=%d", a.Length(), b.Length())
+	}
+}